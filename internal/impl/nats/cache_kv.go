@@ -0,0 +1,169 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func natsKVCacheConfig() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Stable().
+		Categories("Services").
+		Version("3.49.0").
+		Summary("Use a NATS JetStream KV bucket as a cache.").
+		Description(`Stores and retrieves cache items using a https://docs.nats.io/nats-concepts/jetstream/key-value-store[NATS JetStream KV bucket^].
+
+` + connectionNameDescription() + authDescription()).
+		Fields(connectionHeadFields()...).
+		Fields(kvBucketFields()...).
+		Fields(connectionTailFields()...)
+}
+
+func init() {
+	err := service.RegisterCache(
+		"nats_kv", natsKVCacheConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.Cache, error) {
+			return newKVCacheFromConfig(conf, mgr)
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type kvCache struct {
+	connDetails connectionDetails
+	bucketConf  kvBucketConfig
+
+	log *service.Logger
+
+	connMut  sync.Mutex
+	natsConn *nats.Conn
+	kv       nats.KeyValue
+}
+
+func newKVCacheFromConfig(conf *service.ParsedConfig, mgr *service.Resources) (*kvCache, error) {
+	c := kvCache{
+		log: mgr.Logger(),
+	}
+
+	var err error
+	if c.connDetails, err = connectionDetailsFromParsed(conf, mgr); err != nil {
+		return nil, err
+	}
+	if c.bucketConf, err = kvBucketConfigFromParsed(conf); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (c *kvCache) connect(ctx context.Context) (nats.KeyValue, error) {
+	c.connMut.Lock()
+	defer c.connMut.Unlock()
+
+	if c.kv != nil {
+		return c.kv, nil
+	}
+
+	natsConn, err := c.connDetails.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jCtx, err := natsConn.JetStream()
+	if err != nil {
+		natsConn.Close()
+		return nil, err
+	}
+
+	kv, err := c.bucketConf.ensureKeyValue(jCtx)
+	if err != nil {
+		natsConn.Close()
+		return nil, err
+	}
+
+	c.natsConn = natsConn
+	c.kv = kv
+	return kv, nil
+}
+
+func (c *kvCache) Get(ctx context.Context, key string) ([]byte, error) {
+	kv, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := kv.Get(key)
+	if err != nil {
+		if errors.Is(err, nats.ErrKeyNotFound) {
+			return nil, service.ErrKeyNotFound
+		}
+		return nil, err
+	}
+	return entry.Value(), nil
+}
+
+func (c *kvCache) Set(ctx context.Context, key string, value []byte, ttl *time.Duration) error {
+	kv, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = kv.Put(key, value)
+	return err
+}
+
+// Add sets a key only if it doesn't already exist, relying on `Create`, which
+// the server rejects when the key is already present, to detect the race
+// rather than reading-then-writing.
+func (c *kvCache) Add(ctx context.Context, key string, value []byte, ttl *time.Duration) error {
+	kv, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err = kv.Create(key, value); err != nil {
+		if errors.Is(err, nats.ErrKeyExists) {
+			return service.ErrKeyAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (c *kvCache) Delete(ctx context.Context, key string) error {
+	kv, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+	return kv.Delete(key)
+}
+
+func (c *kvCache) Close(ctx context.Context) error {
+	c.connMut.Lock()
+	defer c.connMut.Unlock()
+
+	if c.natsConn != nil {
+		c.natsConn.Close()
+		c.natsConn = nil
+		c.kv = nil
+	}
+	return nil
+}
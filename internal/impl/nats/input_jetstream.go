@@ -19,10 +19,12 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/Jeffail/shutdown"
+	"github.com/dustin/go-humanize"
 	"github.com/nats-io/nats.go"
 	"github.com/redpanda-data/benthos/v4/public/service"
 )
@@ -73,14 +75,42 @@ xref:configuration:interpolation.adoc#bloblang-queries[function interpolation].
 		Field(service.NewBoolField("bind").
 			Description("Indicates that the subscription should use an existing consumer.").
 			Optional()).
+		Field(service.NewBoolField("ordered").
+			Description("Consume using an ephemeral, single-subscriber ordered consumer (`nats.OrderedConsumer()`), which detects delivery gaps and resubscribes under the hood to guarantee in-order delivery. This is the idiomatic way to do replay or materialised-view style consumption from a stream head. Cannot be combined with `durable`, `queue` or `bind`, and `max_ack_pending` is ignored in this mode.").
+			Advanced().
+			Default(false).
+			Version("3.52.0")).
 		Field(service.NewStringAnnotatedEnumField("deliver", map[string]string{
-			"all":              "Deliver all available messages.",
-			"last":             "Deliver starting with the last published messages.",
-			"last_per_subject": "Deliver starting with the last published message per subject.",
-			"new":              "Deliver starting from now, not taking into account any previous messages.",
+			"all":               "Deliver all available messages.",
+			"last":              "Deliver starting with the last published messages.",
+			"last_per_subject":  "Deliver starting with the last published message per subject.",
+			"new":               "Deliver starting from now, not taking into account any previous messages.",
+			"by_start_sequence": "Deliver starting from a given stream sequence, configured via `start_sequence`.",
+			"by_start_time":     "Deliver starting from a given timestamp, configured via `start_time`.",
 		}).
 			Description("Determines which messages to deliver when consuming without a durable subscriber.").
 			Default("all")).
+		Field(service.NewIntField("start_sequence").
+			Description("The stream sequence to start delivering from. Only applies when `deliver` is `by_start_sequence`.").
+			Advanced().
+			Default(0).
+			Version("3.53.0")).
+		Field(service.NewStringField("start_time").
+			Description("An RFC 3339 formatted timestamp to start delivering from. Only applies when `deliver` is `by_start_time`.").
+			Advanced().
+			Default("").
+			Example("2021-01-01T00:00:00Z").
+			Version("3.53.0")).
+		Field(service.NewStringField("deliver_subject").
+			Description("An explicit push delivery subject to use for this consumer. If empty the server generates one automatically.").
+			Advanced().
+			Default("").
+			Version("3.53.0")).
+		Field(service.NewStringField("bind_deliver_subject").
+			Description("Attach directly to an existing push consumer by its delivery subject, without performing a consumer lookup. This allows multiple Benthos instances to fan in on a shared queue-group push consumer.").
+			Advanced().
+			Default("").
+			Version("3.53.0")).
 		Field(service.NewStringField("ack_wait").
 			Description("The maximum amount of time NATS server should wait for an ack from consumer.").
 			Advanced().
@@ -91,6 +121,70 @@ xref:configuration:interpolation.adoc#bloblang-queries[function interpolation].
 			Description("The maximum number of outstanding acks to be allowed before consuming is halted.").
 			Advanced().
 			Default(1024)).
+		Field(service.NewStringField("nak_delay").
+			Description("An optional delay to apply before a nacked message is made available for redelivery, calling `NakWithDelay` instead of `Nak`. An empty string applies no delay.").
+			Advanced().
+			Default("").
+			Example("1s").
+			Version("3.51.0")).
+		Field(service.NewIntField("max_deliver").
+			Description("The maximum number of times a message will be redelivered before it's terminated rather than nacked. A value of `0` leaves this unset, letting the consumer's own configuration (or the server default) apply.").
+			Advanced().
+			Default(0).
+			Version("3.51.0")).
+		Field(service.NewStringListField("terminal_errors").
+			Description("A list of substrings that, when found within a nack error, causes the message to be terminated (via `Term`) rather than nacked, so the server stops redelivering it.").
+			Advanced().
+			Default([]any{}).
+			Version("3.51.0")).
+		Field(service.NewStringField("ack_wait_heartbeat").
+			Description("An optional interval at which an `InProgress` ack is sent to the server for a message batch still being processed, resetting its `ack_wait` timer so that long-running processing doesn't cause it to be considered failed. An empty string disables this.").
+			Advanced().
+			Default("").
+			Example("10s").
+			Version("3.51.0")).
+		Field(service.NewIntField("batch_count").
+			Description("The maximum number of messages to pull from a pull consumer in a single `Fetch` call. Increasing this allows pull consumers to achieve much higher throughput than fetching one message at a time.").
+			Advanced().
+			Default(1).
+			Version("3.48.0")).
+		Field(service.NewStringField("batch_byte_size").
+			Description("An optional limit on the total size (in bytes) of a single pull consumer `Fetch` call. An empty string means no limit is applied.").
+			Advanced().
+			Default("").
+			Example("1MB").
+			Version("3.48.0")).
+		Field(service.NewStringField("batch_period").
+			Description("The maximum amount of time a pull consumer `Fetch` call will wait to fill a batch before returning with however many messages are available.").
+			Advanced().
+			Default("500ms").
+			Version("3.48.0")).
+		Field(service.NewStringField("idle_heartbeat").
+			Description("An optional interval at which the server sends idle heartbeats to this consumer's delivery subject when no messages are flowing. An empty string disables idle heartbeats.").
+			Advanced().
+			Default("").
+			Example("5s").
+			Version("3.48.0")).
+		Field(service.NewBoolField("flow_control").
+			Description("Enables server flow control for push consumers, which throttles message delivery so that a slow consumer cannot be overwhelmed.").
+			Advanced().
+			Default(false).
+			Version("3.48.0")).
+		Field(service.NewIntField("max_waiting").
+			Description("The maximum number of waiting pull requests the server will hold for this pull consumer.").
+			Advanced().
+			Default(512).
+			Version("3.48.0")).
+		Field(service.NewObjectField("create_if_not_exists",
+			service.NewObjectField("stream", streamCreateFields()...).
+				Description("Configuration used to create the target stream when it does not already exist."),
+			service.NewObjectField("consumer", consumerCreateFields()...).
+				Description("Configuration used to create the target durable consumer when it does not already exist. Only applies when the `durable` field is set."),
+		).
+			Description("Allows this input to create the configured stream and/or durable consumer on connect if they do not already exist, rather than failing. This mirrors the provisioning that's otherwise done out of band with `jsm.go` or the NATS CLI tooling.").
+			Advanced().
+			Optional().
+			Version("3.47.0")).
 		Fields(connectionTailFields()...).
 		Field(inputTracingDocs())
 }
@@ -113,16 +207,35 @@ func init() {
 //------------------------------------------------------------------------------
 
 type jetStreamReader struct {
-	connDetails   connectionDetails
-	deliverOpt    nats.SubOpt
-	subject       string
-	queue         string
-	stream        string
-	bind          bool
-	pull          bool
-	durable       string
-	ackWait       time.Duration
-	maxAckPending int
+	connDetails        connectionDetails
+	deliverOpt         nats.SubOpt
+	subject            string
+	queue              string
+	stream             string
+	bind               bool
+	ordered            bool
+	deliverSubject     string
+	bindDeliverSubject string
+	pull               bool
+	durable            string
+	ackWait            time.Duration
+	maxAckPending      int
+
+	nakDelay         time.Duration
+	maxDeliver       int
+	terminalErrors   []string
+	ackWaitHeartbeat time.Duration
+
+	batchCount    int
+	batchByteSize int
+	batchPeriod   time.Duration
+	idleHeartbeat time.Duration
+	flowControl   bool
+	maxWaiting    int
+
+	createIfNotExists    bool
+	createStreamConfig   nats.StreamConfig
+	createConsumerConfig nats.ConsumerConfig
 
 	log *service.Logger
 
@@ -157,6 +270,22 @@ func newJetStreamReaderFromConfig(conf *service.ParsedConfig, mgr *service.Resou
 		j.deliverOpt = nats.DeliverLastPerSubject()
 	case "new":
 		j.deliverOpt = nats.DeliverNew()
+	case "by_start_sequence":
+		startSequence, err := conf.FieldInt("start_sequence")
+		if err != nil {
+			return nil, err
+		}
+		j.deliverOpt = nats.StartSequence(uint64(startSequence))
+	case "by_start_time":
+		startTimeStr, err := conf.FieldString("start_time")
+		if err != nil {
+			return nil, err
+		}
+		startTime, err := time.Parse(time.RFC3339, startTimeStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse start_time: %v", err)
+		}
+		j.deliverOpt = nats.StartTime(startTime)
 	default:
 		return nil, fmt.Errorf("deliver option %v was not recognised", deliver)
 	}
@@ -187,6 +316,18 @@ func newJetStreamReaderFromConfig(conf *service.ParsedConfig, mgr *service.Resou
 			return nil, err
 		}
 	}
+	if j.ordered, err = conf.FieldBool("ordered"); err != nil {
+		return nil, err
+	}
+	if j.deliverSubject, err = conf.FieldString("deliver_subject"); err != nil {
+		return nil, err
+	}
+	if j.bindDeliverSubject, err = conf.FieldString("bind_deliver_subject"); err != nil {
+		return nil, err
+	}
+	if err := validateOrderedConsumerConfig(j.ordered, j.durable, j.queue, j.bind); err != nil {
+		return nil, err
+	}
 	if j.bind {
 		if j.stream == "" && j.durable == "" {
 			return nil, errors.New("stream or durable is required, when bind is true")
@@ -211,9 +352,313 @@ func newJetStreamReaderFromConfig(conf *service.ParsedConfig, mgr *service.Resou
 	if j.maxAckPending, err = conf.FieldInt("max_ack_pending"); err != nil {
 		return nil, err
 	}
+
+	nakDelayStr, err := conf.FieldString("nak_delay")
+	if err != nil {
+		return nil, err
+	}
+	if nakDelayStr != "" {
+		if j.nakDelay, err = time.ParseDuration(nakDelayStr); err != nil {
+			return nil, fmt.Errorf("failed to parse nak_delay duration: %v", err)
+		}
+	}
+
+	if j.maxDeliver, err = conf.FieldInt("max_deliver"); err != nil {
+		return nil, err
+	}
+
+	if j.terminalErrors, err = conf.FieldStringList("terminal_errors"); err != nil {
+		return nil, err
+	}
+
+	ackWaitHeartbeatStr, err := conf.FieldString("ack_wait_heartbeat")
+	if err != nil {
+		return nil, err
+	}
+	if ackWaitHeartbeatStr != "" {
+		if j.ackWaitHeartbeat, err = time.ParseDuration(ackWaitHeartbeatStr); err != nil {
+			return nil, fmt.Errorf("failed to parse ack_wait_heartbeat duration: %v", err)
+		}
+	}
+
+	if j.batchCount, err = conf.FieldInt("batch_count"); err != nil {
+		return nil, err
+	}
+	if j.batchCount < 1 {
+		j.batchCount = 1
+	}
+
+	batchByteSizeStr, err := conf.FieldString("batch_byte_size")
+	if err != nil {
+		return nil, err
+	}
+	if batchByteSizeStr != "" {
+		size, err := humanize.ParseBytes(batchByteSizeStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse batch_byte_size: %v", err)
+		}
+		j.batchByteSize = int(size)
+	}
+
+	batchPeriodStr, err := conf.FieldString("batch_period")
+	if err != nil {
+		return nil, err
+	}
+	if j.batchPeriod, err = time.ParseDuration(batchPeriodStr); err != nil {
+		return nil, fmt.Errorf("failed to parse batch_period duration: %v", err)
+	}
+
+	idleHeartbeatStr, err := conf.FieldString("idle_heartbeat")
+	if err != nil {
+		return nil, err
+	}
+	if idleHeartbeatStr != "" {
+		if j.idleHeartbeat, err = time.ParseDuration(idleHeartbeatStr); err != nil {
+			return nil, fmt.Errorf("failed to parse idle_heartbeat duration: %v", err)
+		}
+	}
+
+	if j.flowControl, err = conf.FieldBool("flow_control"); err != nil {
+		return nil, err
+	}
+
+	if j.maxWaiting, err = conf.FieldInt("max_waiting"); err != nil {
+		return nil, err
+	}
+
+	if conf.Contains("create_if_not_exists") {
+		j.createIfNotExists = true
+
+		createConf := conf.Namespace("create_if_not_exists")
+		if j.createStreamConfig, err = streamConfigFromParsed(createConf.Namespace("stream"), j.stream, j.subject); err != nil {
+			return nil, err
+		}
+		if j.createConsumerConfig, err = consumerConfigFromParsed(createConf.Namespace("consumer"), j.durable, j.subject); err != nil {
+			return nil, err
+		}
+		j.createConsumerConfig.MaxDeliver = j.maxDeliver
+		j.createConsumerConfig.AckWait = j.ackWait
+		j.createConsumerConfig.MaxAckPending = j.maxAckPending
+		j.createConsumerConfig.Heartbeat = j.idleHeartbeat
+		j.createConsumerConfig.FlowControl = j.flowControl
+		j.createConsumerConfig.MaxWaiting = j.maxWaiting
+		if j.queue != "" {
+			j.createConsumerConfig.DeliverGroup = j.queue
+		}
+		if j.deliverSubject != "" {
+			j.createConsumerConfig.DeliverSubject = j.deliverSubject
+		}
+	}
 	return &j, nil
 }
 
+// streamCreateFields are the fields used to configure the stream that's
+// auto-provisioned by `create_if_not_exists`.
+func streamCreateFields() []*service.ConfigField {
+	return []*service.ConfigField{
+		service.NewStringEnumField("storage", "file", "memory").
+			Description("The storage backend to use for the stream.").
+			Default("file"),
+		service.NewIntField("replicas").
+			Description("The number of replicas to keep for the stream.").
+			Default(1),
+		service.NewStringEnumField("retention", "limits", "interest", "workqueue").
+			Description("The retention policy to apply to the stream.").
+			Default("limits"),
+		service.NewStringField("max_age").
+			Description("The maximum age of messages before they're removed from the stream, expressed as a duration string. An empty string disables this limit.").
+			Default("").
+			Example("24h").
+			Example("168h"),
+		service.NewIntField("max_bytes").
+			Description("The maximum size in bytes the stream is allowed to grow to, after which old messages are discarded. A value of `-1` disables this limit.").
+			Default(-1),
+		service.NewIntField("max_msgs").
+			Description("The maximum number of messages the stream is allowed to keep, after which old messages are discarded. A value of `-1` disables this limit.").
+			Default(-1),
+		service.NewStringEnumField("discard", "old", "new").
+			Description("Determines whether the server discards old messages in favour of new ones, or refuses new messages, once a stream limit is reached.").
+			Default("old"),
+		service.NewStringListField("subjects").
+			Description("The list of subjects the stream should capture. If empty the configured `subject` field is used.").
+			Default([]any{}),
+	}
+}
+
+// consumerCreateFields are the fields used to configure the durable consumer
+// that's auto-provisioned by `create_if_not_exists`.
+func consumerCreateFields() []*service.ConfigField {
+	return []*service.ConfigField{
+		service.NewStringEnumField("ack_policy", "none", "all", "explicit").
+			Description("The acknowledgement policy to apply to the durable consumer.").
+			Default("explicit"),
+		service.NewStringEnumField("replay_policy", "instant", "original").
+			Description("The replay policy to apply to the durable consumer.").
+			Default("instant"),
+		service.NewStringField("filter_subject").
+			Description("An optional subject used to filter messages delivered to the consumer. If empty the configured `subject` field is used.").
+			Default(""),
+		service.NewStringField("sample_freq").
+			Description("The percentage of acknowledgements that are sampled for observability, expressed as a string such as `100%` or `30%`.").
+			Default(""),
+		service.NewStringField("inactive_threshold").
+			Description("A duration string after which an inactive consumer with no subscribers is removed by the server. An empty string disables this limit.").
+			Default("").
+			Example("5m"),
+		service.NewStringListField("backoff").
+			Description("A list of duration strings controlling the backoff applied between redeliveries of a message.").
+			Default([]any{}).
+			Example([]any{"1s", "5s", "30s"}),
+	}
+}
+
+func streamConfigFromParsed(conf *service.ParsedConfig, name, subject string) (cfg nats.StreamConfig, err error) {
+	cfg.Name = name
+
+	var storage string
+	if storage, err = conf.FieldString("storage"); err != nil {
+		return
+	}
+	if storage == "memory" {
+		cfg.Storage = nats.MemoryStorage
+	} else {
+		cfg.Storage = nats.FileStorage
+	}
+
+	if cfg.Replicas, err = conf.FieldInt("replicas"); err != nil {
+		return
+	}
+
+	var retention string
+	if retention, err = conf.FieldString("retention"); err != nil {
+		return
+	}
+	switch retention {
+	case "interest":
+		cfg.Retention = nats.InterestPolicy
+	case "workqueue":
+		cfg.Retention = nats.WorkQueuePolicy
+	default:
+		cfg.Retention = nats.LimitsPolicy
+	}
+
+	var maxAgeStr string
+	if maxAgeStr, err = conf.FieldString("max_age"); err != nil {
+		return
+	}
+	if maxAgeStr != "" {
+		if cfg.MaxAge, err = time.ParseDuration(maxAgeStr); err != nil {
+			err = fmt.Errorf("failed to parse max_age duration: %v", err)
+			return
+		}
+	}
+
+	var maxBytes int
+	if maxBytes, err = conf.FieldInt("max_bytes"); err != nil {
+		return
+	}
+	cfg.MaxBytes = int64(maxBytes)
+
+	var maxMsgs int
+	if maxMsgs, err = conf.FieldInt("max_msgs"); err != nil {
+		return
+	}
+	cfg.MaxMsgs = int64(maxMsgs)
+
+	var discard string
+	if discard, err = conf.FieldString("discard"); err != nil {
+		return
+	}
+	if discard == "new" {
+		cfg.Discard = nats.DiscardNew
+	} else {
+		cfg.Discard = nats.DiscardOld
+	}
+
+	if cfg.Subjects, err = conf.FieldStringList("subjects"); err != nil {
+		return
+	}
+	if len(cfg.Subjects) == 0 && subject != "" {
+		cfg.Subjects = []string{subject}
+	}
+	return
+}
+
+func consumerConfigFromParsed(conf *service.ParsedConfig, durable, subject string) (cfg nats.ConsumerConfig, err error) {
+	cfg.Durable = durable
+
+	var ackPolicy string
+	if ackPolicy, err = conf.FieldString("ack_policy"); err != nil {
+		return
+	}
+	switch ackPolicy {
+	case "none":
+		cfg.AckPolicy = nats.AckNonePolicy
+	case "all":
+		cfg.AckPolicy = nats.AckAllPolicy
+	default:
+		cfg.AckPolicy = nats.AckExplicitPolicy
+	}
+
+	var replayPolicy string
+	if replayPolicy, err = conf.FieldString("replay_policy"); err != nil {
+		return
+	}
+	if replayPolicy == "original" {
+		cfg.ReplayPolicy = nats.ReplayOriginal
+	} else {
+		cfg.ReplayPolicy = nats.ReplayInstant
+	}
+
+	if cfg.FilterSubject, err = conf.FieldString("filter_subject"); err != nil {
+		return
+	}
+	if cfg.FilterSubject == "" {
+		cfg.FilterSubject = subject
+	}
+
+	if cfg.SampleFrequency, err = conf.FieldString("sample_freq"); err != nil {
+		return
+	}
+
+	var inactiveThresholdStr string
+	if inactiveThresholdStr, err = conf.FieldString("inactive_threshold"); err != nil {
+		return
+	}
+	if inactiveThresholdStr != "" {
+		if cfg.InactiveThreshold, err = time.ParseDuration(inactiveThresholdStr); err != nil {
+			err = fmt.Errorf("failed to parse inactive_threshold duration: %v", err)
+			return
+		}
+	}
+
+	var backoffStrs []string
+	if backoffStrs, err = conf.FieldStringList("backoff"); err != nil {
+		return
+	}
+	for _, s := range backoffStrs {
+		var d time.Duration
+		if d, err = time.ParseDuration(s); err != nil {
+			err = fmt.Errorf("failed to parse backoff duration: %v", err)
+			return
+		}
+		cfg.BackOff = append(cfg.BackOff, d)
+	}
+	return
+}
+
+// validateOrderedConsumerConfig rejects durable, queue and bind when ordered
+// is set, since ordered consumers are ephemeral and single-subscriber.
+func validateOrderedConsumerConfig(ordered bool, durable, queue string, bind bool) error {
+	if !ordered {
+		return nil
+	}
+	if durable != "" || queue != "" || bind {
+		return errors.New("durable, queue and bind are not supported when ordered is true, ordered consumers are ephemeral and single-subscriber")
+	}
+	return nil
+}
+
 //------------------------------------------------------------------------------
 
 func (j *jetStreamReader) Connect(ctx context.Context) (err error) {
@@ -247,6 +692,12 @@ func (j *jetStreamReader) Connect(ctx context.Context) (err error) {
 		return err
 	}
 
+	if j.createIfNotExists {
+		if err = j.createStreamAndConsumer(jCtx); err != nil {
+			return err
+		}
+	}
+
 	if j.bind && j.stream != "" && j.durable != "" {
 		info, err := jCtx.ConsumerInfo(j.stream, j.durable)
 		if err != nil {
@@ -264,12 +715,41 @@ func (j *jetStreamReader) Connect(ctx context.Context) (err error) {
 		j.pull = info.Config.DeliverSubject == ""
 	}
 
-	options := []nats.SubOpt{
-		nats.ManualAck(),
+	var options []nats.SubOpt
+	if !j.ordered {
+		options = append(options, nats.ManualAck())
+	}
+
+	if j.ackWait > 0 {
+		options = append(options, nats.AckWait(j.ackWait))
+	}
+	if j.maxAckPending != 0 && !j.ordered {
+		options = append(options, nats.MaxAckPending(j.maxAckPending))
+	}
+	if j.ordered {
+		options = append(options, nats.OrderedConsumer())
+	}
+	if j.idleHeartbeat > 0 {
+		options = append(options, nats.IdleHeartbeat(j.idleHeartbeat))
+	}
+	if j.flowControl {
+		options = append(options, nats.EnableFlowControl())
+	}
+	if j.maxDeliver > 0 {
+		options = append(options, nats.MaxDeliver(j.maxDeliver))
+	}
+	if j.deliverSubject != "" {
+		options = append(options, nats.DeliverSubject(j.deliverSubject))
+	}
+	if j.bindDeliverSubject != "" {
+		options = append(options, nats.BindDeliverSubject(j.bindDeliverSubject))
 	}
 
 	if j.pull {
 		options = append(options, nats.Bind(j.stream, j.durable))
+		if j.maxWaiting > 0 {
+			options = append(options, nats.PullMaxWaiting(j.maxWaiting))
+		}
 
 		natsSub, err = jCtx.PullSubscribe(j.subject, j.durable, options...)
 	} else {
@@ -277,12 +757,6 @@ func (j *jetStreamReader) Connect(ctx context.Context) (err error) {
 			options = append(options, nats.Durable(j.durable))
 		}
 		options = append(options, j.deliverOpt)
-		if j.ackWait > 0 {
-			options = append(options, nats.AckWait(j.ackWait))
-		}
-		if j.maxAckPending != 0 {
-			options = append(options, nats.MaxAckPending(j.maxAckPending))
-		}
 
 		if j.bind && j.stream != "" && j.durable != "" {
 			options = append(options, nats.Bind(j.stream, j.durable))
@@ -305,6 +779,96 @@ func (j *jetStreamReader) Connect(ctx context.Context) (err error) {
 	return nil
 }
 
+// createStreamAndConsumer provisions the configured stream and/or durable
+// consumer when they don't already exist, allowing the pipeline to
+// self-bootstrap instead of failing on connect.
+func (j *jetStreamReader) createStreamAndConsumer(jCtx nats.JetStreamContext) error {
+	if j.stream != "" {
+		if _, err := jCtx.StreamInfo(j.stream); err != nil {
+			if !errors.Is(err, nats.ErrStreamNotFound) {
+				return err
+			}
+			if _, err := jCtx.AddStream(&j.createStreamConfig); err != nil {
+				return fmt.Errorf("failed to create stream %v: %w", j.stream, err)
+			}
+		}
+	}
+
+	if j.stream != "" && j.durable != "" {
+		if _, err := jCtx.ConsumerInfo(j.stream, j.durable); err != nil {
+			if !errors.Is(err, nats.ErrConsumerNotFound) {
+				return err
+			}
+			if _, err := jCtx.AddConsumer(j.stream, &j.createConsumerConfig); err != nil {
+				return fmt.Errorf("failed to create durable consumer %v: %w", j.durable, err)
+			}
+		}
+	}
+	return nil
+}
+
+// nakOrTerm nacks a message, unless nakErr matches one of the configured
+// terminal_errors or the message has reached max_deliver deliveries, in which
+// case the message is terminated instead so the server stops redelivering it.
+func (j *jetStreamReader) nakOrTerm(m *nats.Msg, nakErr error) error {
+	if isTerminalError(j.terminalErrors, nakErr) {
+		return m.Term()
+	}
+	if j.maxDeliver > 0 {
+		if meta, err := m.Metadata(); err == nil && meta.NumDelivered >= uint64(j.maxDeliver) {
+			return m.Term()
+		}
+	}
+	if j.nakDelay > 0 {
+		return m.NakWithDelay(j.nakDelay)
+	}
+	return m.Nak()
+}
+
+// isTerminalError returns true when nakErr matches one of the configured
+// terminal_errors substrings, indicating the message should be terminated
+// rather than nacked for redelivery.
+func isTerminalError(terminalErrors []string, nakErr error) bool {
+	for _, sub := range terminalErrors {
+		if strings.Contains(nakErr.Error(), sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// startAckWaitHeartbeat periodically sends an InProgress ack for every
+// message in a batch that's still being processed, resetting the server's
+// ack_wait timer so long-running processing isn't mistaken for a stalled
+// consumer. The returned function must be called once the batch has been
+// acked or nacked to stop the heartbeat.
+func (j *jetStreamReader) startAckWaitHeartbeat(msgs []*nats.Msg) (stop func()) {
+	if j.ackWaitHeartbeat <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(j.ackWaitHeartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				for _, m := range msgs {
+					_ = m.InProgress()
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
 func (j *jetStreamReader) disconnect() {
 	j.connMut.Lock()
 	defer j.connMut.Unlock()
@@ -349,17 +913,31 @@ func (j *jetStreamReader) ReadBatch(ctx context.Context) (service.MessageBatch,
 			return nil, nil, err
 		}
 
+		if j.ordered {
+			return service.MessageBatch{convertMessage(nmsg)}, func(ctx context.Context, err error) error {
+				// Ordered consumers use an implicit ack-none policy; the
+				// library itself handles gap detection and resubscription.
+				return nil
+			}, nil
+		}
+
+		stopHeartbeat := j.startAckWaitHeartbeat([]*nats.Msg{nmsg})
 		return service.MessageBatch{convertMessage(nmsg)}, func(ctx context.Context, err error) error {
+			stopHeartbeat()
 			if err != nil {
-				return nmsg.Nak()
+				return j.nakOrTerm(nmsg, err)
 			}
 			return nmsg.Ack()
 		}, nil
 	}
 
-	var buf = make([]*service.Message, 10)
+	fetchOpts := []nats.PullOpt{nats.Context(ctx), nats.MaxWait(j.batchPeriod)}
+	if j.batchByteSize > 0 {
+		fetchOpts = append(fetchOpts, nats.MaxBytes(j.batchByteSize))
+	}
+
 	for {
-		msgs, err := natsSub.Fetch(1, nats.Context(ctx), nats.MaxWait(500*time.Millisecond))
+		msgs, err := natsSub.Fetch(j.batchCount, fetchOpts...)
 		if err != nil {
 			if errors.Is(err, nats.ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
 				// NATS enforces its own context that might time out faster than the original context
@@ -377,16 +955,17 @@ func (j *jetStreamReader) ReadBatch(ctx context.Context) (service.MessageBatch,
 			continue
 		}
 
+		batch := make(service.MessageBatch, len(msgs))
 		for i, m := range msgs {
-			msg := convertMessage(m).
-				WithContext(ctx)
-			buf[i] = msg
+			batch[i] = convertMessage(m).WithContext(ctx)
 		}
 
-		return buf[:len(msgs)], func(ctx context.Context, err error) error {
+		stopHeartbeat := j.startAckWaitHeartbeat(msgs)
+		return batch, func(ctx context.Context, err error) error {
+			stopHeartbeat()
 			if err != nil {
 				for _, v := range msgs {
-					if err = v.Nak(); err != nil {
+					if err := j.nakOrTerm(v, err); err != nil {
 						return err
 					}
 				}
@@ -394,7 +973,7 @@ func (j *jetStreamReader) ReadBatch(ctx context.Context) (service.MessageBatch,
 			}
 
 			for _, v := range msgs {
-				if err = v.Ack(); err != nil {
+				if err := v.Ack(); err != nil {
 					return err
 				}
 			}
@@ -0,0 +1,44 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsTerminalError(t *testing.T) {
+	tests := []struct {
+		name           string
+		terminalErrors []string
+		err            error
+		want           bool
+	}{
+		{name: "no terminal errors configured", err: errors.New("boom")},
+		{name: "no match", terminalErrors: []string{"poison"}, err: errors.New("boom")},
+		{name: "exact match", terminalErrors: []string{"boom"}, err: errors.New("boom"), want: true},
+		{name: "substring match", terminalErrors: []string{"unparseable"}, err: errors.New("message was unparseable: bad json"), want: true},
+		{name: "matches one of several", terminalErrors: []string{"nope", "boom"}, err: errors.New("boom"), want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := isTerminalError(test.terminalErrors, test.err)
+			if got != test.want {
+				t.Fatalf("isTerminalError(%v, %v) = %v, want %v", test.terminalErrors, test.err, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func parseStreamCreateConfig(t *testing.T, yamlStr, name, subject string) nats.StreamConfig {
+	t.Helper()
+
+	spec := service.NewConfigSpec().Fields(streamCreateFields()...)
+	parsed, err := spec.ParseYAML(yamlStr, nil)
+	if err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+
+	cfg, err := streamConfigFromParsed(parsed, name, subject)
+	if err != nil {
+		t.Fatalf("streamConfigFromParsed returned an error: %v", err)
+	}
+	return cfg
+}
+
+func TestStreamConfigFromParsedDefaults(t *testing.T) {
+	cfg := parseStreamCreateConfig(t, ``, "my_stream", "foo.bar")
+
+	if cfg.Name != "my_stream" {
+		t.Errorf("Name = %q, want %q", cfg.Name, "my_stream")
+	}
+	if cfg.Storage != nats.FileStorage {
+		t.Errorf("Storage = %v, want FileStorage", cfg.Storage)
+	}
+	if cfg.Replicas != 1 {
+		t.Errorf("Replicas = %v, want 1", cfg.Replicas)
+	}
+	if cfg.Retention != nats.LimitsPolicy {
+		t.Errorf("Retention = %v, want LimitsPolicy", cfg.Retention)
+	}
+	if cfg.MaxAge != 0 {
+		t.Errorf("MaxAge = %v, want 0", cfg.MaxAge)
+	}
+	if cfg.MaxBytes != -1 {
+		t.Errorf("MaxBytes = %v, want -1", cfg.MaxBytes)
+	}
+	if cfg.MaxMsgs != -1 {
+		t.Errorf("MaxMsgs = %v, want -1", cfg.MaxMsgs)
+	}
+	if cfg.Discard != nats.DiscardOld {
+		t.Errorf("Discard = %v, want DiscardOld", cfg.Discard)
+	}
+	if len(cfg.Subjects) != 1 || cfg.Subjects[0] != "foo.bar" {
+		t.Errorf("Subjects = %v, want [foo.bar] (falls back to subject)", cfg.Subjects)
+	}
+}
+
+func TestStreamConfigFromParsedOverrides(t *testing.T) {
+	cfg := parseStreamCreateConfig(t, `
+storage: memory
+replicas: 3
+retention: workqueue
+max_age: 24h
+max_bytes: 1024
+max_msgs: 100
+discard: new
+subjects: [ foo.*, bar.> ]
+`, "my_stream", "foo.bar")
+
+	if cfg.Storage != nats.MemoryStorage {
+		t.Errorf("Storage = %v, want MemoryStorage", cfg.Storage)
+	}
+	if cfg.Replicas != 3 {
+		t.Errorf("Replicas = %v, want 3", cfg.Replicas)
+	}
+	if cfg.Retention != nats.WorkQueuePolicy {
+		t.Errorf("Retention = %v, want WorkQueuePolicy", cfg.Retention)
+	}
+	if cfg.MaxAge != 24*time.Hour {
+		t.Errorf("MaxAge = %v, want 24h", cfg.MaxAge)
+	}
+	if cfg.MaxBytes != 1024 {
+		t.Errorf("MaxBytes = %v, want 1024", cfg.MaxBytes)
+	}
+	if cfg.MaxMsgs != 100 {
+		t.Errorf("MaxMsgs = %v, want 100", cfg.MaxMsgs)
+	}
+	if cfg.Discard != nats.DiscardNew {
+		t.Errorf("Discard = %v, want DiscardNew", cfg.Discard)
+	}
+	if len(cfg.Subjects) != 2 || cfg.Subjects[0] != "foo.*" || cfg.Subjects[1] != "bar.>" {
+		t.Errorf("Subjects = %v, want explicit list preserved", cfg.Subjects)
+	}
+}
+
+func TestStreamConfigFromParsedInvalidMaxAge(t *testing.T) {
+	spec := service.NewConfigSpec().Fields(streamCreateFields()...)
+	parsed, err := spec.ParseYAML(`max_age: not-a-duration`, nil)
+	if err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+
+	if _, err := streamConfigFromParsed(parsed, "my_stream", "foo.bar"); err == nil {
+		t.Fatal("expected an error parsing an invalid max_age duration")
+	}
+}
+
+func parseConsumerCreateConfig(t *testing.T, yamlStr, durable, subject string) nats.ConsumerConfig {
+	t.Helper()
+
+	spec := service.NewConfigSpec().Fields(consumerCreateFields()...)
+	parsed, err := spec.ParseYAML(yamlStr, nil)
+	if err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+
+	cfg, err := consumerConfigFromParsed(parsed, durable, subject)
+	if err != nil {
+		t.Fatalf("consumerConfigFromParsed returned an error: %v", err)
+	}
+	return cfg
+}
+
+func TestConsumerConfigFromParsedDefaults(t *testing.T) {
+	cfg := parseConsumerCreateConfig(t, ``, "my_durable", "foo.bar")
+
+	if cfg.Durable != "my_durable" {
+		t.Errorf("Durable = %q, want %q", cfg.Durable, "my_durable")
+	}
+	if cfg.AckPolicy != nats.AckExplicitPolicy {
+		t.Errorf("AckPolicy = %v, want AckExplicitPolicy", cfg.AckPolicy)
+	}
+	if cfg.ReplayPolicy != nats.ReplayInstant {
+		t.Errorf("ReplayPolicy = %v, want ReplayInstant", cfg.ReplayPolicy)
+	}
+	if cfg.FilterSubject != "foo.bar" {
+		t.Errorf("FilterSubject = %q, want %q (falls back to subject)", cfg.FilterSubject, "foo.bar")
+	}
+	if cfg.SampleFrequency != "" {
+		t.Errorf("SampleFrequency = %q, want empty", cfg.SampleFrequency)
+	}
+	if cfg.InactiveThreshold != 0 {
+		t.Errorf("InactiveThreshold = %v, want 0", cfg.InactiveThreshold)
+	}
+	if len(cfg.BackOff) != 0 {
+		t.Errorf("BackOff = %v, want empty", cfg.BackOff)
+	}
+}
+
+func TestConsumerConfigFromParsedOverrides(t *testing.T) {
+	cfg := parseConsumerCreateConfig(t, `
+ack_policy: none
+replay_policy: original
+filter_subject: foo.baz
+sample_freq: "30%"
+inactive_threshold: 5m
+backoff: [ 1s, 5s, 30s ]
+`, "my_durable", "foo.bar")
+
+	if cfg.AckPolicy != nats.AckNonePolicy {
+		t.Errorf("AckPolicy = %v, want AckNonePolicy", cfg.AckPolicy)
+	}
+	if cfg.ReplayPolicy != nats.ReplayOriginal {
+		t.Errorf("ReplayPolicy = %v, want ReplayOriginal", cfg.ReplayPolicy)
+	}
+	if cfg.FilterSubject != "foo.baz" {
+		t.Errorf("FilterSubject = %q, want %q (explicit override wins)", cfg.FilterSubject, "foo.baz")
+	}
+	if cfg.SampleFrequency != "30%" {
+		t.Errorf("SampleFrequency = %q, want 30%%", cfg.SampleFrequency)
+	}
+	if cfg.InactiveThreshold != 5*time.Minute {
+		t.Errorf("InactiveThreshold = %v, want 5m", cfg.InactiveThreshold)
+	}
+	if len(cfg.BackOff) != 3 || cfg.BackOff[0] != time.Second || cfg.BackOff[1] != 5*time.Second || cfg.BackOff[2] != 30*time.Second {
+		t.Errorf("BackOff = %v, want [1s 5s 30s]", cfg.BackOff)
+	}
+}
+
+func TestConsumerConfigFromParsedInvalidBackoff(t *testing.T) {
+	spec := service.NewConfigSpec().Fields(consumerCreateFields()...)
+	parsed, err := spec.ParseYAML(`backoff: [ not-a-duration ]`, nil)
+	if err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+
+	if _, err := consumerConfigFromParsed(parsed, "my_durable", "foo.bar"); err == nil {
+		t.Fatal("expected an error parsing an invalid backoff duration")
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import "testing"
+
+func TestValidateOrderedConsumerConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		ordered bool
+		durable string
+		queue   string
+		bind    bool
+		wantErr bool
+	}{
+		{name: "not ordered is always fine", ordered: false, durable: "d", queue: "q", bind: true},
+		{name: "ordered with nothing else set", ordered: true},
+		{name: "ordered with durable", ordered: true, durable: "d", wantErr: true},
+		{name: "ordered with queue", ordered: true, queue: "q", wantErr: true},
+		{name: "ordered with bind", ordered: true, bind: true, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateOrderedConsumerConfig(test.ordered, test.durable, test.queue, test.bind)
+			if test.wantErr && err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+			if !test.wantErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
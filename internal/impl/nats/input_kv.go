@@ -0,0 +1,208 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Jeffail/shutdown"
+	"github.com/nats-io/nats.go"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func natsKVInputConfig() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Stable().
+		Categories("Services").
+		Version("3.49.0").
+		Summary("Watches a NATS JetStream KV bucket for changes and emits a message per update.").
+		Description(`
+== Metadata
+
+This input adds the following metadata fields to each message:
+
+` + "```text" + `
+- nats_kv_key
+- nats_kv_revision
+- nats_kv_operation
+- nats_kv_created
+` + "```" + `
+
+You can access these metadata fields using
+xref:configuration:interpolation.adoc#bloblang-queries[function interpolation].
+
+` + connectionNameDescription() + authDescription()).
+		Fields(connectionHeadFields()...).
+		Fields(kvBucketFields()...).
+		Field(service.NewStringListField("keys").
+			Description("An optional list of key patterns to watch. If empty all keys in the bucket are watched.").
+			Default([]any{}).
+			Example([]any{"foo.bar.*"})).
+		Field(service.NewBoolField("include_history").
+			Description("Deliver the full history of values for each watched key, rather than only new changes.").
+			Advanced().
+			Default(false)).
+		Fields(connectionTailFields()...).
+		Field(inputTracingDocs())
+}
+
+func init() {
+	err := service.RegisterInput(
+		"nats_kv", natsKVInputConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.Input, error) {
+			input, err := newKVInputFromConfig(conf, mgr)
+			if err != nil {
+				return nil, err
+			}
+			return conf.WrapInputExtractTracingSpanMapping("nats_kv", input)
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type kvInput struct {
+	connDetails    connectionDetails
+	bucketConf     kvBucketConfig
+	keys           []string
+	includeHistory bool
+
+	log *service.Logger
+
+	connMut  sync.Mutex
+	natsConn *nats.Conn
+	watcher  nats.KeyWatcher
+
+	shutSig *shutdown.Signaller
+}
+
+func newKVInputFromConfig(conf *service.ParsedConfig, mgr *service.Resources) (*kvInput, error) {
+	i := kvInput{
+		log:     mgr.Logger(),
+		shutSig: shutdown.NewSignaller(),
+	}
+
+	var err error
+	if i.connDetails, err = connectionDetailsFromParsed(conf, mgr); err != nil {
+		return nil, err
+	}
+	if i.bucketConf, err = kvBucketConfigFromParsed(conf); err != nil {
+		return nil, err
+	}
+	if i.keys, err = conf.FieldStringList("keys"); err != nil {
+		return nil, err
+	}
+	if i.includeHistory, err = conf.FieldBool("include_history"); err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+func (i *kvInput) Connect(ctx context.Context) error {
+	i.connMut.Lock()
+	defer i.connMut.Unlock()
+
+	if i.natsConn != nil {
+		return nil
+	}
+
+	natsConn, err := i.connDetails.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	jCtx, err := natsConn.JetStream()
+	if err != nil {
+		natsConn.Close()
+		return err
+	}
+
+	kv, err := i.bucketConf.ensureKeyValue(jCtx)
+	if err != nil {
+		natsConn.Close()
+		return err
+	}
+
+	var watchOpts []nats.WatchOpt
+	if i.includeHistory {
+		watchOpts = append(watchOpts, nats.IncludeHistory())
+	} else {
+		watchOpts = append(watchOpts, nats.UpdatesOnly())
+	}
+
+	var watcher nats.KeyWatcher
+	if len(i.keys) == 0 {
+		watcher, err = kv.WatchAll(watchOpts...)
+	} else if len(i.keys) == 1 {
+		watcher, err = kv.Watch(i.keys[0], watchOpts...)
+	} else {
+		watcher, err = kv.WatchFiltered(i.keys, watchOpts...)
+	}
+	if err != nil {
+		natsConn.Close()
+		return err
+	}
+
+	i.natsConn = natsConn
+	i.watcher = watcher
+	return nil
+}
+
+func (i *kvInput) Read(ctx context.Context) (*service.Message, service.AckFunc, error) {
+	i.connMut.Lock()
+	watcher := i.watcher
+	i.connMut.Unlock()
+	if watcher == nil {
+		return nil, nil, service.ErrNotConnected
+	}
+
+	select {
+	case entry, open := <-watcher.Updates():
+		if !open {
+			return nil, nil, service.ErrNotConnected
+		}
+		if entry == nil {
+			// A nil entry with the channel still open marks the end of the
+			// initial historical replay, it carries no data of its own.
+			return i.Read(ctx)
+		}
+		return kvEntryToMessage(ctx, entry), func(ctx context.Context, err error) error {
+			return nil
+		}, nil
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case <-i.shutSig.HasStoppedChan():
+		return nil, nil, service.ErrEndOfInput
+	}
+}
+
+func (i *kvInput) Close(ctx context.Context) error {
+	i.connMut.Lock()
+	defer i.connMut.Unlock()
+
+	i.shutSig.TriggerHasStopped()
+	if i.watcher != nil {
+		_ = i.watcher.Stop()
+		i.watcher = nil
+	}
+	if i.natsConn != nil {
+		i.natsConn.Close()
+		i.natsConn = nil
+	}
+	return nil
+}
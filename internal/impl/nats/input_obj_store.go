@@ -0,0 +1,224 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/Jeffail/shutdown"
+	"github.com/nats-io/nats.go"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func natsObjectStoreInputConfig() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Stable().
+		Categories("Services").
+		Version("3.50.0").
+		Summary("Watches a NATS JetStream object store for new and updated objects and emits a message per object.").
+		Description(`
+== Metadata
+
+This input adds the following metadata fields to each message:
+
+` + "```text" + `
+- nats_obj_name
+- nats_obj_bucket
+- nats_obj_size
+- nats_obj_digest
+- nats_obj_mtime
+- nats_obj_nuid
+` + "```" + `
+
+You can access these metadata fields using
+xref:configuration:interpolation.adoc#bloblang-queries[function interpolation].
+
+` + connectionNameDescription() + authDescription()).
+		Fields(connectionHeadFields()...).
+		Fields(objStoreBucketFields()...).
+		Field(service.NewBoolField("include_history").
+			Description("Deliver the full history of each object, rather than only new and updated objects.").
+			Advanced().
+			Default(false)).
+		Fields(connectionTailFields()...).
+		Field(inputTracingDocs())
+}
+
+func init() {
+	err := service.RegisterInput(
+		"nats_object_store", natsObjectStoreInputConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.Input, error) {
+			input, err := newObjStoreInputFromConfig(conf, mgr)
+			if err != nil {
+				return nil, err
+			}
+			return conf.WrapInputExtractTracingSpanMapping("nats_object_store", input)
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type objStoreInput struct {
+	connDetails    connectionDetails
+	bucketConf     objStoreBucketConfig
+	includeHistory bool
+
+	log *service.Logger
+
+	connMut  sync.Mutex
+	natsConn *nats.Conn
+	obs      nats.ObjectStore
+	watcher  nats.ObjectWatcher
+
+	shutSig *shutdown.Signaller
+}
+
+func newObjStoreInputFromConfig(conf *service.ParsedConfig, mgr *service.Resources) (*objStoreInput, error) {
+	i := objStoreInput{
+		log:     mgr.Logger(),
+		shutSig: shutdown.NewSignaller(),
+	}
+
+	var err error
+	if i.connDetails, err = connectionDetailsFromParsed(conf, mgr); err != nil {
+		return nil, err
+	}
+	if i.bucketConf, err = objStoreBucketConfigFromParsed(conf); err != nil {
+		return nil, err
+	}
+	if i.includeHistory, err = conf.FieldBool("include_history"); err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+func (i *objStoreInput) Connect(ctx context.Context) error {
+	i.connMut.Lock()
+	defer i.connMut.Unlock()
+
+	if i.natsConn != nil {
+		return nil
+	}
+
+	natsConn, err := i.connDetails.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	jCtx, err := natsConn.JetStream()
+	if err != nil {
+		natsConn.Close()
+		return err
+	}
+
+	obs, err := i.bucketConf.ensureObjectStore(jCtx)
+	if err != nil {
+		natsConn.Close()
+		return err
+	}
+
+	var watchOpts []nats.WatchOpt
+	if i.includeHistory {
+		watchOpts = append(watchOpts, nats.IncludeHistory())
+	} else {
+		watchOpts = append(watchOpts, nats.UpdatesOnly())
+	}
+
+	watcher, err := obs.Watch(watchOpts...)
+	if err != nil {
+		natsConn.Close()
+		return err
+	}
+
+	i.natsConn = natsConn
+	i.obs = obs
+	i.watcher = watcher
+	return nil
+}
+
+func (i *objStoreInput) Read(ctx context.Context) (*service.Message, service.AckFunc, error) {
+	i.connMut.Lock()
+	watcher := i.watcher
+	obs := i.obs
+	i.connMut.Unlock()
+	if watcher == nil {
+		return nil, nil, service.ErrNotConnected
+	}
+
+	select {
+	case info, open := <-watcher.Updates():
+		if !open {
+			return nil, nil, service.ErrNotConnected
+		}
+		if info == nil {
+			// A nil entry with the channel still open marks the end of the
+			// initial historical replay, it carries no data of its own.
+			return i.Read(ctx)
+		}
+		if info.Deleted {
+			return i.Read(ctx)
+		}
+
+		var buf bytes.Buffer
+		reader, err := obs.Get(info.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		_, err = io.Copy(&buf, reader)
+		_ = reader.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		msg := service.NewMessage(buf.Bytes())
+		msg.MetaSet("nats_obj_name", info.Name)
+		msg.MetaSet("nats_obj_bucket", info.Bucket)
+		msg.MetaSet("nats_obj_size", strconv.FormatUint(info.Size, 10))
+		msg.MetaSet("nats_obj_digest", info.Digest)
+		msg.MetaSet("nats_obj_mtime", info.ModTime.Format("2006-01-02T15:04:05.000000000Z07:00"))
+		msg.MetaSet("nats_obj_nuid", info.NUID)
+
+		return msg.WithContext(ctx), func(ctx context.Context, err error) error {
+			return nil
+		}, nil
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case <-i.shutSig.HasStoppedChan():
+		return nil, nil, service.ErrEndOfInput
+	}
+}
+
+func (i *objStoreInput) Close(ctx context.Context) error {
+	i.connMut.Lock()
+	defer i.connMut.Unlock()
+
+	i.shutSig.TriggerHasStopped()
+	if i.watcher != nil {
+		_ = i.watcher.Stop()
+		i.watcher = nil
+	}
+	if i.natsConn != nil {
+		i.natsConn.Close()
+		i.natsConn = nil
+	}
+	return nil
+}
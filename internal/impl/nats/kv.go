@@ -0,0 +1,171 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+// kvMaxHistory is the upper bound the NATS server enforces on a KV bucket's
+// `history` setting.
+const kvMaxHistory = 64
+
+// kvBucketFields are the fields shared by every `nats_kv` component for
+// locating, and optionally auto-creating, the target KV bucket.
+func kvBucketFields() []*service.ConfigField {
+	return []*service.ConfigField{
+		service.NewStringField("bucket").
+			Description("The name of the KV bucket.").
+			Example("my_kv_bucket"),
+		service.NewBoolField("create_if_not_exists").
+			Description("Create the bucket if it does not already exist.").
+			Advanced().
+			Default(false),
+		service.NewIntField("history").
+			Description("The number of historical values to keep per key. Only applies when `create_if_not_exists` is set.").
+			Advanced().
+			Default(1),
+		service.NewStringField("ttl").
+			Description("The maximum age of values stored in the bucket before they're removed, expressed as a duration string. An empty string disables this limit. Only applies when `create_if_not_exists` is set.").
+			Advanced().
+			Default("").
+			Example("24h"),
+		service.NewIntField("replicas").
+			Description("The number of replicas to keep for the bucket. Only applies when `create_if_not_exists` is set.").
+			Advanced().
+			Default(1),
+		service.NewStringEnumField("storage", "file", "memory").
+			Description("The storage backend to use for the bucket. Only applies when `create_if_not_exists` is set.").
+			Advanced().
+			Default("file"),
+		service.NewIntField("max_value_size").
+			Description("The maximum size of a value, in bytes, that can be stored under a single key. A value of `-1` disables this limit. Only applies when `create_if_not_exists` is set.").
+			Advanced().
+			Default(-1),
+	}
+}
+
+type kvBucketConfig struct {
+	bucket            string
+	createIfNotExists bool
+	history           int
+	ttl               time.Duration
+	replicas          int
+	storage           nats.StorageType
+	maxValueSize      int32
+}
+
+func kvBucketConfigFromParsed(conf *service.ParsedConfig) (cfg kvBucketConfig, err error) {
+	if cfg.bucket, err = conf.FieldString("bucket"); err != nil {
+		return
+	}
+	if cfg.createIfNotExists, err = conf.FieldBool("create_if_not_exists"); err != nil {
+		return
+	}
+	if cfg.history, err = conf.FieldInt("history"); err != nil {
+		return
+	}
+	if cfg.history < 1 || cfg.history > kvMaxHistory {
+		err = fmt.Errorf("history must be between 1 and %d, got %v", kvMaxHistory, cfg.history)
+		return
+	}
+
+	ttlStr, err := conf.FieldString("ttl")
+	if err != nil {
+		return
+	}
+	if ttlStr != "" {
+		if cfg.ttl, err = time.ParseDuration(ttlStr); err != nil {
+			err = fmt.Errorf("failed to parse ttl duration: %v", err)
+			return
+		}
+	}
+
+	if cfg.replicas, err = conf.FieldInt("replicas"); err != nil {
+		return
+	}
+
+	storageStr, err := conf.FieldString("storage")
+	if err != nil {
+		return
+	}
+	if storageStr == "memory" {
+		cfg.storage = nats.MemoryStorage
+	} else {
+		cfg.storage = nats.FileStorage
+	}
+
+	maxValueSize, err := conf.FieldInt("max_value_size")
+	if err != nil {
+		return
+	}
+	if maxValueSize != -1 && (maxValueSize < 0 || maxValueSize > math.MaxInt32) {
+		err = fmt.Errorf("max_value_size must be -1 or between 0 and %d, got %v", math.MaxInt32, maxValueSize)
+		return
+	}
+	cfg.maxValueSize = int32(maxValueSize)
+	return
+}
+
+// ensureKeyValue returns a handle to the configured KV bucket, creating it
+// first if requested and it doesn't already exist.
+func (c kvBucketConfig) ensureKeyValue(jCtx nats.JetStreamContext) (nats.KeyValue, error) {
+	kv, err := jCtx.KeyValue(c.bucket)
+	if err == nil {
+		return kv, nil
+	}
+	if !c.createIfNotExists || !errors.Is(err, nats.ErrBucketNotFound) {
+		return nil, err
+	}
+	return jCtx.CreateKeyValue(&nats.KeyValueConfig{
+		Bucket:       c.bucket,
+		History:      uint8(c.history),
+		TTL:          c.ttl,
+		Replicas:     c.replicas,
+		Storage:      c.storage,
+		MaxValueSize: c.maxValueSize,
+	})
+}
+
+// kvWatchOperationString maps a nats.go KV watch operation to the string
+// value stored in the `nats_kv_operation` metadata field.
+func kvWatchOperationString(op nats.KeyValueOp) string {
+	switch op {
+	case nats.KeyValuePut:
+		return "put"
+	case nats.KeyValueDelete:
+		return "delete"
+	case nats.KeyValuePurge:
+		return "purge"
+	default:
+		return "unknown"
+	}
+}
+
+func kvEntryToMessage(ctx context.Context, entry nats.KeyValueEntry) *service.Message {
+	msg := service.NewMessage(entry.Value())
+	msg.MetaSet("nats_kv_key", entry.Key())
+	msg.MetaSet("nats_kv_revision", fmt.Sprintf("%v", entry.Revision()))
+	msg.MetaSet("nats_kv_operation", kvWatchOperationString(entry.Operation()))
+	msg.MetaSet("nats_kv_created", entry.Created().Format(time.RFC3339Nano))
+	return msg.WithContext(ctx)
+}
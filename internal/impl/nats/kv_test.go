@@ -0,0 +1,144 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func parseKVBucketConfig(t *testing.T, yamlStr string) kvBucketConfig {
+	t.Helper()
+
+	spec := service.NewConfigSpec().Fields(kvBucketFields()...)
+	parsed, err := spec.ParseYAML(yamlStr, nil)
+	if err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+
+	conf, err := kvBucketConfigFromParsed(parsed)
+	if err != nil {
+		t.Fatalf("kvBucketConfigFromParsed returned an error: %v", err)
+	}
+	return conf
+}
+
+func TestKVBucketConfigFromParsedDefaults(t *testing.T) {
+	conf := parseKVBucketConfig(t, `bucket: my_bucket`)
+
+	if conf.bucket != "my_bucket" {
+		t.Errorf("bucket = %q, want %q", conf.bucket, "my_bucket")
+	}
+	if conf.createIfNotExists {
+		t.Error("createIfNotExists should default to false")
+	}
+	if conf.history != 1 {
+		t.Errorf("history = %v, want 1", conf.history)
+	}
+	if conf.ttl != 0 {
+		t.Errorf("ttl = %v, want 0", conf.ttl)
+	}
+	if conf.replicas != 1 {
+		t.Errorf("replicas = %v, want 1", conf.replicas)
+	}
+	if conf.storage != nats.FileStorage {
+		t.Errorf("storage = %v, want FileStorage", conf.storage)
+	}
+	if conf.maxValueSize != -1 {
+		t.Errorf("maxValueSize = %v, want -1", conf.maxValueSize)
+	}
+}
+
+func TestKVBucketConfigFromParsedOverrides(t *testing.T) {
+	conf := parseKVBucketConfig(t, `
+bucket: my_bucket
+create_if_not_exists: true
+history: 5
+ttl: 1h
+replicas: 3
+storage: memory
+max_value_size: 2048
+`)
+
+	if !conf.createIfNotExists {
+		t.Error("createIfNotExists should be true")
+	}
+	if conf.history != 5 {
+		t.Errorf("history = %v, want 5", conf.history)
+	}
+	if conf.ttl != time.Hour {
+		t.Errorf("ttl = %v, want 1h", conf.ttl)
+	}
+	if conf.replicas != 3 {
+		t.Errorf("replicas = %v, want 3", conf.replicas)
+	}
+	if conf.storage != nats.MemoryStorage {
+		t.Errorf("storage = %v, want MemoryStorage", conf.storage)
+	}
+	if conf.maxValueSize != 2048 {
+		t.Errorf("maxValueSize = %v, want 2048", conf.maxValueSize)
+	}
+}
+
+func TestKVBucketConfigFromParsedInvalidTTL(t *testing.T) {
+	spec := service.NewConfigSpec().Fields(kvBucketFields()...)
+	parsed, err := spec.ParseYAML(`
+bucket: my_bucket
+ttl: not-a-duration
+`, nil)
+	if err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+
+	if _, err := kvBucketConfigFromParsed(parsed); err == nil {
+		t.Fatal("expected an error parsing an invalid ttl duration")
+	}
+}
+
+func TestKVBucketConfigFromParsedHistoryOutOfRange(t *testing.T) {
+	for _, history := range []int{0, -1, 65, 300} {
+		spec := service.NewConfigSpec().Fields(kvBucketFields()...)
+		parsed, err := spec.ParseYAML(fmt.Sprintf(`
+bucket: my_bucket
+history: %d
+`, history), nil)
+		if err != nil {
+			t.Fatalf("failed to parse config: %v", err)
+		}
+
+		if _, err := kvBucketConfigFromParsed(parsed); err == nil {
+			t.Errorf("history = %v: expected an error, got nil", history)
+		}
+	}
+}
+
+func TestKVBucketConfigFromParsedMaxValueSizeOutOfRange(t *testing.T) {
+	spec := service.NewConfigSpec().Fields(kvBucketFields()...)
+	parsed, err := spec.ParseYAML(`
+bucket: my_bucket
+max_value_size: -2
+`, nil)
+	if err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+
+	if _, err := kvBucketConfigFromParsed(parsed); err == nil {
+		t.Fatal("expected an error for a max_value_size below -1")
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+// objStoreBucketFields are the fields shared by the `nats_object_store`
+// input and output for locating, and optionally auto-creating, the target
+// object store bucket.
+func objStoreBucketFields() []*service.ConfigField {
+	return []*service.ConfigField{
+		service.NewStringField("bucket").
+			Description("The name of the object store bucket.").
+			Example("my_object_bucket"),
+		service.NewBoolField("create_if_not_exists").
+			Description("Create the bucket if it does not already exist.").
+			Advanced().
+			Default(false),
+		service.NewStringField("ttl").
+			Description("The maximum age of objects stored in the bucket before they're removed, expressed as a duration string. An empty string disables this limit. Only applies when `create_if_not_exists` is set.").
+			Advanced().
+			Default("").
+			Example("24h"),
+		service.NewIntField("replicas").
+			Description("The number of replicas to keep for the bucket. Only applies when `create_if_not_exists` is set.").
+			Advanced().
+			Default(1),
+		service.NewStringEnumField("storage", "file", "memory").
+			Description("The storage backend to use for the bucket. Only applies when `create_if_not_exists` is set.").
+			Advanced().
+			Default("file"),
+	}
+}
+
+type objStoreBucketConfig struct {
+	bucket            string
+	createIfNotExists bool
+	ttl               time.Duration
+	replicas          int
+	storage           nats.StorageType
+}
+
+func objStoreBucketConfigFromParsed(conf *service.ParsedConfig) (cfg objStoreBucketConfig, err error) {
+	if cfg.bucket, err = conf.FieldString("bucket"); err != nil {
+		return
+	}
+	if cfg.createIfNotExists, err = conf.FieldBool("create_if_not_exists"); err != nil {
+		return
+	}
+
+	ttlStr, err := conf.FieldString("ttl")
+	if err != nil {
+		return
+	}
+	if ttlStr != "" {
+		if cfg.ttl, err = time.ParseDuration(ttlStr); err != nil {
+			err = fmt.Errorf("failed to parse ttl duration: %v", err)
+			return
+		}
+	}
+
+	if cfg.replicas, err = conf.FieldInt("replicas"); err != nil {
+		return
+	}
+
+	storageStr, err := conf.FieldString("storage")
+	if err != nil {
+		return
+	}
+	if storageStr == "memory" {
+		cfg.storage = nats.MemoryStorage
+	} else {
+		cfg.storage = nats.FileStorage
+	}
+	return
+}
+
+// ensureObjectStore returns a handle to the configured object store bucket,
+// creating it first if requested and it doesn't already exist.
+func (c objStoreBucketConfig) ensureObjectStore(jCtx nats.JetStreamContext) (nats.ObjectStore, error) {
+	obs, err := jCtx.ObjectStore(c.bucket)
+	if err == nil {
+		return obs, nil
+	}
+	if !c.createIfNotExists || !errors.Is(err, nats.ErrBucketNotFound) {
+		return nil, err
+	}
+	return jCtx.CreateObjectStore(&nats.ObjectStoreConfig{
+		Bucket:   c.bucket,
+		TTL:      c.ttl,
+		Replicas: c.replicas,
+		Storage:  c.storage,
+	})
+}
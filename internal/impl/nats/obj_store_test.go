@@ -0,0 +1,97 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func parseObjStoreBucketConfig(t *testing.T, yamlStr string) objStoreBucketConfig {
+	t.Helper()
+
+	spec := service.NewConfigSpec().Fields(objStoreBucketFields()...)
+	parsed, err := spec.ParseYAML(yamlStr, nil)
+	if err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+
+	conf, err := objStoreBucketConfigFromParsed(parsed)
+	if err != nil {
+		t.Fatalf("objStoreBucketConfigFromParsed returned an error: %v", err)
+	}
+	return conf
+}
+
+func TestObjStoreBucketConfigFromParsedDefaults(t *testing.T) {
+	conf := parseObjStoreBucketConfig(t, `bucket: my_bucket`)
+
+	if conf.bucket != "my_bucket" {
+		t.Errorf("bucket = %q, want %q", conf.bucket, "my_bucket")
+	}
+	if conf.createIfNotExists {
+		t.Error("createIfNotExists should default to false")
+	}
+	if conf.ttl != 0 {
+		t.Errorf("ttl = %v, want 0", conf.ttl)
+	}
+	if conf.replicas != 1 {
+		t.Errorf("replicas = %v, want 1", conf.replicas)
+	}
+	if conf.storage != nats.FileStorage {
+		t.Errorf("storage = %v, want FileStorage", conf.storage)
+	}
+}
+
+func TestObjStoreBucketConfigFromParsedOverrides(t *testing.T) {
+	conf := parseObjStoreBucketConfig(t, `
+bucket: my_bucket
+create_if_not_exists: true
+ttl: 2h
+replicas: 5
+storage: memory
+`)
+
+	if !conf.createIfNotExists {
+		t.Error("createIfNotExists should be true")
+	}
+	if conf.ttl != 2*time.Hour {
+		t.Errorf("ttl = %v, want 2h", conf.ttl)
+	}
+	if conf.replicas != 5 {
+		t.Errorf("replicas = %v, want 5", conf.replicas)
+	}
+	if conf.storage != nats.MemoryStorage {
+		t.Errorf("storage = %v, want MemoryStorage", conf.storage)
+	}
+}
+
+func TestObjStoreBucketConfigFromParsedInvalidTTL(t *testing.T) {
+	spec := service.NewConfigSpec().Fields(objStoreBucketFields()...)
+	parsed, err := spec.ParseYAML(`
+bucket: my_bucket
+ttl: not-a-duration
+`, nil)
+	if err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+
+	if _, err := objStoreBucketConfigFromParsed(parsed); err == nil {
+		t.Fatal("expected an error parsing an invalid ttl duration")
+	}
+}
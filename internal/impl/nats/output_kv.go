@@ -0,0 +1,154 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func natsKVOutputConfig() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Stable().
+		Categories("Services").
+		Version("3.49.0").
+		Summary("Writes messages to a NATS JetStream KV bucket.").
+		Description(`Each message is written to the bucket under the key computed from the ` + "`key`" + ` field.
+
+` + connectionNameDescription() + authDescription()).
+		Fields(connectionHeadFields()...).
+		Fields(kvBucketFields()...).
+		Field(service.NewInterpolatedStringField("key").
+			Description("The key to store the message under.").
+			Example(`${! meta("id") }`)).
+		Field(service.NewIntField("max_in_flight").
+			Description("The maximum number of messages to have in flight at a given time. Increase this to improve throughput.").
+			Default(64)).
+		Fields(connectionTailFields()...)
+}
+
+func init() {
+	err := service.RegisterOutput(
+		"nats_kv", natsKVOutputConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (out service.Output, maxInFlight int, err error) {
+			if maxInFlight, err = conf.FieldInt("max_in_flight"); err != nil {
+				return nil, 0, err
+			}
+			out, err = newKVOutputFromConfig(conf, mgr)
+			return
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type kvOutput struct {
+	connDetails connectionDetails
+	bucketConf  kvBucketConfig
+	key         *service.InterpolatedString
+
+	log *service.Logger
+
+	connMut  sync.Mutex
+	natsConn *nats.Conn
+	kv       nats.KeyValue
+}
+
+func newKVOutputFromConfig(conf *service.ParsedConfig, mgr *service.Resources) (*kvOutput, error) {
+	o := kvOutput{
+		log: mgr.Logger(),
+	}
+
+	var err error
+	if o.connDetails, err = connectionDetailsFromParsed(conf, mgr); err != nil {
+		return nil, err
+	}
+	if o.bucketConf, err = kvBucketConfigFromParsed(conf); err != nil {
+		return nil, err
+	}
+	if o.key, err = conf.FieldInterpolatedString("key"); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (o *kvOutput) Connect(ctx context.Context) error {
+	o.connMut.Lock()
+	defer o.connMut.Unlock()
+
+	if o.natsConn != nil {
+		return nil
+	}
+
+	natsConn, err := o.connDetails.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	jCtx, err := natsConn.JetStream()
+	if err != nil {
+		natsConn.Close()
+		return err
+	}
+
+	kv, err := o.bucketConf.ensureKeyValue(jCtx)
+	if err != nil {
+		natsConn.Close()
+		return err
+	}
+
+	o.natsConn = natsConn
+	o.kv = kv
+	return nil
+}
+
+func (o *kvOutput) Write(ctx context.Context, msg *service.Message) error {
+	o.connMut.Lock()
+	kv := o.kv
+	o.connMut.Unlock()
+	if kv == nil {
+		return service.ErrNotConnected
+	}
+
+	key, err := o.key.TryString(msg)
+	if err != nil {
+		return err
+	}
+
+	value, err := msg.AsBytes()
+	if err != nil {
+		return err
+	}
+
+	_, err = kv.Put(key, value)
+	return err
+}
+
+func (o *kvOutput) Close(ctx context.Context) error {
+	o.connMut.Lock()
+	defer o.connMut.Unlock()
+
+	if o.natsConn != nil {
+		o.natsConn.Close()
+		o.natsConn = nil
+		o.kv = nil
+	}
+	return nil
+}
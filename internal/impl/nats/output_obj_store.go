@@ -0,0 +1,190 @@
+// Copyright 2024 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func natsObjectStoreOutputConfig() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Stable().
+		Categories("Services").
+		Version("3.50.0").
+		Summary("Writes messages to a NATS JetStream object store.").
+		Description(`Each message is streamed into the bucket as a distinct object.
+
+` + connectionNameDescription() + authDescription()).
+		Fields(connectionHeadFields()...).
+		Fields(objStoreBucketFields()...).
+		Field(service.NewInterpolatedStringField("name").
+			Description("The name to store the object under.").
+			Example(`${! meta("id") }`)).
+		Field(service.NewInterpolatedStringField("description").
+			Description("An optional description to attach to the object.").
+			Advanced().
+			Default("")).
+		Field(service.NewInterpolatedStringMapField("headers").
+			Description("An optional map of headers to attach to the object.").
+			Advanced().
+			Default(map[string]any{})).
+		Field(service.NewIntField("max_in_flight").
+			Description("The maximum number of messages to have in flight at a given time. Increase this to improve throughput.").
+			Default(64)).
+		Fields(connectionTailFields()...)
+}
+
+func init() {
+	err := service.RegisterOutput(
+		"nats_object_store", natsObjectStoreOutputConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (out service.Output, maxInFlight int, err error) {
+			if maxInFlight, err = conf.FieldInt("max_in_flight"); err != nil {
+				return nil, 0, err
+			}
+			out, err = newObjStoreOutputFromConfig(conf, mgr)
+			return
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type objStoreOutput struct {
+	connDetails connectionDetails
+	bucketConf  objStoreBucketConfig
+	name        *service.InterpolatedString
+	description *service.InterpolatedString
+	headers     *service.InterpolatedStringMap
+
+	log *service.Logger
+
+	connMut  sync.Mutex
+	natsConn *nats.Conn
+	obs      nats.ObjectStore
+}
+
+func newObjStoreOutputFromConfig(conf *service.ParsedConfig, mgr *service.Resources) (*objStoreOutput, error) {
+	o := objStoreOutput{
+		log: mgr.Logger(),
+	}
+
+	var err error
+	if o.connDetails, err = connectionDetailsFromParsed(conf, mgr); err != nil {
+		return nil, err
+	}
+	if o.bucketConf, err = objStoreBucketConfigFromParsed(conf); err != nil {
+		return nil, err
+	}
+	if o.name, err = conf.FieldInterpolatedString("name"); err != nil {
+		return nil, err
+	}
+	if o.description, err = conf.FieldInterpolatedString("description"); err != nil {
+		return nil, err
+	}
+	if o.headers, err = conf.FieldInterpolatedStringMap("headers"); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (o *objStoreOutput) Connect(ctx context.Context) error {
+	o.connMut.Lock()
+	defer o.connMut.Unlock()
+
+	if o.natsConn != nil {
+		return nil
+	}
+
+	natsConn, err := o.connDetails.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	jCtx, err := natsConn.JetStream()
+	if err != nil {
+		natsConn.Close()
+		return err
+	}
+
+	obs, err := o.bucketConf.ensureObjectStore(jCtx)
+	if err != nil {
+		natsConn.Close()
+		return err
+	}
+
+	o.natsConn = natsConn
+	o.obs = obs
+	return nil
+}
+
+func (o *objStoreOutput) Write(ctx context.Context, msg *service.Message) error {
+	o.connMut.Lock()
+	obs := o.obs
+	o.connMut.Unlock()
+	if obs == nil {
+		return service.ErrNotConnected
+	}
+
+	name, err := o.name.TryString(msg)
+	if err != nil {
+		return err
+	}
+	description, err := o.description.TryString(msg)
+	if err != nil {
+		return err
+	}
+	headers, err := o.headers.TryInterpolatedStrings(msg)
+	if err != nil {
+		return err
+	}
+
+	meta := nats.ObjectMeta{
+		Name:        name,
+		Description: description,
+	}
+	if len(headers) > 0 {
+		meta.Headers = nats.ObjectMetaHeaders{}
+		for k, v := range headers {
+			meta.Headers.Set(k, v)
+		}
+	}
+
+	value, err := msg.AsBytes()
+	if err != nil {
+		return err
+	}
+
+	_, err = obs.Put(&meta, bytes.NewReader(value))
+	return err
+}
+
+func (o *objStoreOutput) Close(ctx context.Context) error {
+	o.connMut.Lock()
+	defer o.connMut.Unlock()
+
+	if o.natsConn != nil {
+		o.natsConn.Close()
+		o.natsConn = nil
+		o.obs = nil
+	}
+	return nil
+}